@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2019 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package analyzer inspects cluster state for common failure modes
+// (CrashLoopBackOff pods, services with no endpoints, pending PVCs, and
+// the like) and surfaces them as Findings, the same way a k8sgpt-style
+// analyzer registry does.
+package analyzer
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/heptio/developer-dash/internal/cache"
+)
+
+// Severity describes how urgently a Finding needs attention.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Finding describes one problem an Analyzer found with a cluster object.
+type Finding struct {
+	Severity     Severity
+	Kind         string
+	Namespace    string
+	Name         string
+	Reason       string
+	SuggestedFix string
+}
+
+// Analyzer inspects cluster state for a known failure mode and reports
+// what it found. Third-party plugins can implement Analyzer and register
+// their own with a Registry alongside the built-ins.
+type Analyzer interface {
+	// Name uniquely identifies the analyzer, e.g. "crash-loop-backoff".
+	Name() string
+	// Analyze inspects namespace (empty string means all namespaces) and
+	// returns any findings.
+	Analyze(ctx context.Context, c cache.Cache, namespace string) ([]Finding, error)
+}
+
+// Registry holds the set of analyzers to run against a cluster cache.
+type Registry struct {
+	mu        sync.RWMutex
+	analyzers map[string]Analyzer
+}
+
+// NewRegistry creates an instance of Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		analyzers: make(map[string]Analyzer),
+	}
+}
+
+// NewDefaultRegistry creates a Registry populated with the built-in
+// analyzers.
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+
+	for _, a := range BuiltIns() {
+		r.Register(a)
+	}
+
+	return r
+}
+
+// Register adds a to the registry, replacing any existing analyzer with
+// the same name.
+func (r *Registry) Register(a Analyzer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.analyzers[a.Name()] = a
+}
+
+// Analyzers returns the registered analyzers sorted by name.
+func (r *Registry) Analyzers() []Analyzer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]Analyzer, 0, len(r.analyzers))
+	for _, a := range r.analyzers {
+		list = append(list, a)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name() < list[j].Name()
+	})
+
+	return list
+}
+
+// Run executes every registered analyzer against namespace and
+// concatenates their findings. One analyzer's error does not stop the
+// others from running; all errors are joined and returned alongside
+// whatever findings succeeded.
+func (r *Registry) Run(ctx context.Context, c cache.Cache, namespace string) ([]Finding, error) {
+	var findings []Finding
+	var runErr error
+
+	for _, a := range r.Analyzers() {
+		found, err := a.Analyze(ctx, c, namespace)
+		if err != nil {
+			runErr = multiError(runErr, errors.Wrap(err, a.Name()))
+			continue
+		}
+
+		findings = append(findings, found...)
+	}
+
+	return findings, runErr
+}
+
+func multiError(existing, next error) error {
+	if existing == nil {
+		return next
+	}
+
+	return errors.Wrap(next, existing.Error())
+}
@@ -0,0 +1,15 @@
+package analyzer
+
+// BuiltIns returns the analyzers Octant ships out of the box, covering the
+// most common reasons a cluster object silently stops working.
+func BuiltIns() []Analyzer {
+	return []Analyzer{
+		&crashLoopBackOffAnalyzer{},
+		&imagePullBackOffAnalyzer{},
+		&unschedulablePodAnalyzer{},
+		&serviceNoEndpointsAnalyzer{},
+		&pvcPendingAnalyzer{},
+		&webhookUnreachableAnalyzer{},
+		&serviceAccountTokenExpiredAnalyzer{},
+	}
+}
@@ -0,0 +1,163 @@
+package analyzer
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/heptio/developer-dash/internal/cache"
+)
+
+func listPods(ctx context.Context, c cache.Cache, namespace string) ([]*unstructured.Unstructured, error) {
+	return c.List(ctx, cache.Key{
+		Namespace:  namespace,
+		APIVersion: "v1",
+		Kind:       "Pod",
+	})
+}
+
+// crashLoopBackOffAnalyzer flags pods with a container stuck in
+// CrashLoopBackOff.
+type crashLoopBackOffAnalyzer struct{}
+
+var _ Analyzer = (*crashLoopBackOffAnalyzer)(nil)
+
+func (a *crashLoopBackOffAnalyzer) Name() string {
+	return "pod-crash-loop-backoff"
+}
+
+func (a *crashLoopBackOffAnalyzer) Analyze(ctx context.Context, c cache.Cache, namespace string) ([]Finding, error) {
+	pods, err := listPods(ctx, c, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, pod := range pods {
+		if reason, ok := waitingReason(pod, "CrashLoopBackOff"); ok {
+			findings = append(findings, Finding{
+				Severity:     SeverityCritical,
+				Kind:         "Pod",
+				Namespace:    pod.GetNamespace(),
+				Name:         pod.GetName(),
+				Reason:       reason,
+				SuggestedFix: "check container logs for the crashing process and correct its startup command or readiness probe",
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// imagePullBackOffAnalyzer flags pods that cannot pull their image.
+type imagePullBackOffAnalyzer struct{}
+
+var _ Analyzer = (*imagePullBackOffAnalyzer)(nil)
+
+func (a *imagePullBackOffAnalyzer) Name() string {
+	return "pod-image-pull-backoff"
+}
+
+func (a *imagePullBackOffAnalyzer) Analyze(ctx context.Context, c cache.Cache, namespace string) ([]Finding, error) {
+	pods, err := listPods(ctx, c, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, pod := range pods {
+		if reason, ok := waitingReason(pod, "ImagePullBackOff"); ok {
+			findings = append(findings, Finding{
+				Severity:     SeverityWarning,
+				Kind:         "Pod",
+				Namespace:    pod.GetNamespace(),
+				Name:         pod.GetName(),
+				Reason:       reason,
+				SuggestedFix: "verify the image name/tag exists and that imagePullSecrets grants access to the registry",
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// unschedulablePodAnalyzer flags pods the scheduler cannot place.
+type unschedulablePodAnalyzer struct{}
+
+var _ Analyzer = (*unschedulablePodAnalyzer)(nil)
+
+func (a *unschedulablePodAnalyzer) Name() string {
+	return "pod-unschedulable"
+}
+
+func (a *unschedulablePodAnalyzer) Analyze(ctx context.Context, c cache.Cache, namespace string) ([]Finding, error) {
+	pods, err := listPods(ctx, c, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, pod := range pods {
+		conditions, _, err := unstructured.NestedSlice(pod.Object, "status", "conditions")
+		if err != nil {
+			continue
+		}
+
+		for _, cond := range conditions {
+			condition, ok := cond.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if condition["type"] == "PodScheduled" && condition["status"] == "False" {
+				reason, _, _ := unstructured.NestedString(condition, "reason")
+				if reason != "Unschedulable" {
+					continue
+				}
+
+				message, _, _ := unstructured.NestedString(condition, "message")
+
+				findings = append(findings, Finding{
+					Severity:     SeverityWarning,
+					Kind:         "Pod",
+					Namespace:    pod.GetNamespace(),
+					Name:         pod.GetName(),
+					Reason:       message,
+					SuggestedFix: "check node capacity, taints, and the pod's resource requests/affinity rules",
+				})
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// waitingReason returns the container status message if any container in
+// pod is waiting with the given reason.
+func waitingReason(pod *unstructured.Unstructured, wantReason string) (string, bool) {
+	statuses, _, err := unstructured.NestedSlice(pod.Object, "status", "containerStatuses")
+	if err != nil {
+		return "", false
+	}
+
+	for _, s := range statuses {
+		status, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		reason, _, _ := unstructured.NestedString(status, "state", "waiting", "reason")
+		if reason != wantReason {
+			continue
+		}
+
+		message, _, _ := unstructured.NestedString(status, "state", "waiting", "message")
+		if message == "" {
+			message = reason
+		}
+
+		return message, true
+	}
+
+	return "", false
+}
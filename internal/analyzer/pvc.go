@@ -0,0 +1,49 @@
+package analyzer
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/heptio/developer-dash/internal/cache"
+)
+
+// pvcPendingAnalyzer flags PersistentVolumeClaims stuck in Pending,
+// usually because no StorageClass/PersistentVolume satisfies the claim.
+type pvcPendingAnalyzer struct{}
+
+var _ Analyzer = (*pvcPendingAnalyzer)(nil)
+
+func (a *pvcPendingAnalyzer) Name() string {
+	return "pvc-pending"
+}
+
+func (a *pvcPendingAnalyzer) Analyze(ctx context.Context, c cache.Cache, namespace string) ([]Finding, error) {
+	pvcs, err := c.List(ctx, cache.Key{
+		Namespace:  namespace,
+		APIVersion: "v1",
+		Kind:       "PersistentVolumeClaim",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, pvc := range pvcs {
+		phase, _, _ := unstructured.NestedString(pvc.Object, "status", "phase")
+		if phase != "Pending" {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Severity:     SeverityWarning,
+			Kind:         "PersistentVolumeClaim",
+			Namespace:    pvc.GetNamespace(),
+			Name:         pvc.GetName(),
+			Reason:       "claim has been pending and is not bound to a volume",
+			SuggestedFix: "check that a StorageClass/PersistentVolume exists that satisfies the claim's access mode and size",
+		})
+	}
+
+	return findings, nil
+}
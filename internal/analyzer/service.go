@@ -0,0 +1,85 @@
+package analyzer
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/heptio/developer-dash/internal/cache"
+)
+
+// serviceNoEndpointsAnalyzer flags Services with no ready endpoints, which
+// usually means the selector doesn't match any running pod.
+type serviceNoEndpointsAnalyzer struct{}
+
+var _ Analyzer = (*serviceNoEndpointsAnalyzer)(nil)
+
+func (a *serviceNoEndpointsAnalyzer) Name() string {
+	return "service-no-endpoints"
+}
+
+func (a *serviceNoEndpointsAnalyzer) Analyze(ctx context.Context, c cache.Cache, namespace string) ([]Finding, error) {
+	services, err := c.List(ctx, cache.Key{
+		Namespace:  namespace,
+		APIVersion: "v1",
+		Kind:       "Service",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, svc := range services {
+		selector, _, _ := unstructured.NestedStringMap(svc.Object, "spec", "selector")
+		if len(selector) == 0 {
+			// services without a selector are expected to have no endpoints
+			// (e.g. headless services backed by an ExternalName or manually
+			// managed Endpoints object).
+			continue
+		}
+
+		endpoints, err := c.Get(ctx, cache.Key{
+			Namespace:  svc.GetNamespace(),
+			APIVersion: "v1",
+			Kind:       "Endpoints",
+			Name:       svc.GetName(),
+		})
+		// A failed lookup is treated the same as no ready endpoints: either
+		// way the service can't be reached, and the cause is worth
+		// surfacing rather than silently skipping, matching how
+		// webhookUnreachableAnalyzer handles the same failure.
+		if err == nil && endpoints != nil {
+			subsets, _, _ := unstructured.NestedSlice(endpoints.Object, "subsets")
+			if hasReadyAddress(subsets) {
+				continue
+			}
+		}
+
+		findings = append(findings, Finding{
+			Severity:     SeverityWarning,
+			Kind:         "Service",
+			Namespace:    svc.GetNamespace(),
+			Name:         svc.GetName(),
+			Reason:       "service has no ready endpoints",
+			SuggestedFix: "confirm the service's selector matches labels on at least one running, ready pod",
+		})
+	}
+
+	return findings, nil
+}
+
+func hasReadyAddress(subsets []interface{}) bool {
+	for _, s := range subsets {
+		subset, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		addresses, _, _ := unstructured.NestedSlice(subset, "addresses")
+		if len(addresses) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
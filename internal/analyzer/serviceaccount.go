@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/heptio/developer-dash/internal/cache"
+)
+
+// serviceAccountTokenExpiredAnalyzer flags ServiceAccount token Secrets
+// whose bound token has already expired, which otherwise surfaces only as
+// a confusing 401 from the API server.
+type serviceAccountTokenExpiredAnalyzer struct{}
+
+var _ Analyzer = (*serviceAccountTokenExpiredAnalyzer)(nil)
+
+func (a *serviceAccountTokenExpiredAnalyzer) Name() string {
+	return "service-account-token-expired"
+}
+
+func (a *serviceAccountTokenExpiredAnalyzer) Analyze(ctx context.Context, c cache.Cache, namespace string) ([]Finding, error) {
+	secrets, err := c.List(ctx, cache.Key{
+		Namespace:  namespace,
+		APIVersion: "v1",
+		Kind:       "Secret",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, secret := range secrets {
+		secretType, _, _ := unstructured.NestedString(secret.Object, "type")
+		if secretType != "kubernetes.io/service-account-token" {
+			continue
+		}
+
+		expiresAt, _, _ := unstructured.NestedString(secret.Object, "metadata", "annotations", "kubernetes.io/expires-at")
+		if expiresAt == "" {
+			continue
+		}
+
+		expiry, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			continue
+		}
+
+		if time.Now().After(expiry) {
+			findings = append(findings, Finding{
+				Severity:     SeverityWarning,
+				Kind:         "Secret",
+				Namespace:    secret.GetNamespace(),
+				Name:         secret.GetName(),
+				Reason:       "service account token expired at " + expiry.Format(time.RFC3339),
+				SuggestedFix: "rotate the token by deleting the secret so the controller manager reissues it, or switch callers to TokenRequest-issued tokens",
+			})
+		}
+	}
+
+	return findings, nil
+}
@@ -0,0 +1,86 @@
+package analyzer
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/heptio/developer-dash/internal/cache"
+)
+
+// webhookUnreachableAnalyzer flags ValidatingWebhookConfigurations that
+// point at a Service which has no ready endpoints, which would otherwise
+// silently block every request the webhook applies to.
+type webhookUnreachableAnalyzer struct{}
+
+var _ Analyzer = (*webhookUnreachableAnalyzer)(nil)
+
+func (a *webhookUnreachableAnalyzer) Name() string {
+	return "validating-webhook-unreachable"
+}
+
+func (a *webhookUnreachableAnalyzer) Analyze(ctx context.Context, c cache.Cache, namespace string) ([]Finding, error) {
+	configs, err := c.List(ctx, cache.Key{
+		APIVersion: "admissionregistration.k8s.io/v1",
+		Kind:       "ValidatingWebhookConfiguration",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, config := range configs {
+		webhooks, _, _ := unstructured.NestedSlice(config.Object, "webhooks")
+
+		for _, w := range webhooks {
+			webhook, ok := w.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			svcName, _, _ := unstructured.NestedString(webhook, "clientConfig", "service", "name")
+			svcNamespace, _, _ := unstructured.NestedString(webhook, "clientConfig", "service", "namespace")
+			if svcName == "" {
+				// webhooks backed by an external URL rather than an
+				// in-cluster service are out of scope for this analyzer.
+				continue
+			}
+
+			if namespace != "" && svcNamespace != namespace {
+				continue
+			}
+
+			endpoints, err := c.Get(ctx, cache.Key{
+				Namespace:  svcNamespace,
+				APIVersion: "v1",
+				Kind:       "Endpoints",
+				Name:       svcName,
+			})
+			if err != nil || endpoints == nil {
+				findings = append(findings, Finding{
+					Severity:     SeverityCritical,
+					Kind:         "ValidatingWebhookConfiguration",
+					Namespace:    svcNamespace,
+					Name:         config.GetName(),
+					Reason:       "webhook service " + svcNamespace + "/" + svcName + " has no endpoints",
+					SuggestedFix: "confirm the webhook's backing service and pods are running, since an unreachable webhook can block all matching requests",
+				})
+				continue
+			}
+
+			subsets, _, _ := unstructured.NestedSlice(endpoints.Object, "subsets")
+			if !hasReadyAddress(subsets) {
+				findings = append(findings, Finding{
+					Severity:     SeverityCritical,
+					Kind:         "ValidatingWebhookConfiguration",
+					Namespace:    svcNamespace,
+					Name:         config.GetName(),
+					Reason:       "webhook service " + svcNamespace + "/" + svcName + " has no ready endpoints",
+					SuggestedFix: "confirm the webhook's backing service and pods are running, since an unreachable webhook can block all matching requests",
+				})
+			}
+		}
+	}
+
+	return findings, nil
+}
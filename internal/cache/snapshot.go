@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2019 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// SnapshotCache is a read-only Cache backed by a directory tree a
+// overview.SnapshotWriter previously dumped to disk
+// (<path>/<context>/<kind>/<namespace>/<name>.yaml), so a cluster can be
+// triaged offline without a live API server.
+type SnapshotCache struct {
+	path        string
+	contextName string
+}
+
+var _ Cache = (*SnapshotCache)(nil)
+
+// NewSnapshotCache creates a SnapshotCache rooted at path for contextName.
+func NewSnapshotCache(path, contextName string) *SnapshotCache {
+	return &SnapshotCache{path: path, contextName: contextName}
+}
+
+func (c *SnapshotCache) contextDir() string {
+	return filepath.Join(c.path, c.contextName)
+}
+
+// Get returns the object named by key, or nil if the snapshot has nothing
+// at that path.
+func (c *SnapshotCache) Get(ctx context.Context, key Key) (*unstructured.Unstructured, error) {
+	if key.Name == "" {
+		return nil, errors.New("get requires a name")
+	}
+
+	file := filepath.Join(c.contextDir(), key.Kind, key.Namespace, key.Name+".yaml")
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "read snapshot file %s", file)
+	}
+
+	object := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(data, &object.Object); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal snapshot file %s", file)
+	}
+
+	return object, nil
+}
+
+// List returns every object the snapshot captured under key.Kind. If
+// key.Namespace is empty, it returns objects across every namespace (and
+// any cluster-scoped objects written directly under the kind).
+func (c *SnapshotCache) List(ctx context.Context, key Key) ([]*unstructured.Unstructured, error) {
+	root := filepath.Join(c.contextDir(), key.Kind, key.Namespace)
+
+	if _, err := os.Stat(root); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "stat snapshot directory %s", root)
+	}
+
+	var list []*unstructured.Unstructured
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "read snapshot file %s", path)
+		}
+
+		object := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(data, &object.Object); err != nil {
+			return errors.Wrapf(err, "unmarshal snapshot file %s", path)
+		}
+
+		list = append(list, object)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
@@ -0,0 +1,28 @@
+/*
+Copyright (c) 2019 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package cluster holds the client used to talk to a Kubernetes API
+// server.
+package cluster
+
+import (
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// ClientInterface is the set of clients ClusterOverview and its
+// collaborators use to reach a Kubernetes API server.
+type ClientInterface interface {
+	// KubernetesClient returns a typed clientset for the cluster.
+	KubernetesClient() (kubernetes.Interface, error)
+	// DiscoveryClient returns a client for discovering the API groups and
+	// resources the cluster serves.
+	DiscoveryClient() (discovery.DiscoveryInterface, error)
+	// RESTClient returns a generic REST client for the cluster.
+	RESTClient() (rest.Interface, error)
+	// RESTConfig returns the REST config the client was built from.
+	RESTConfig() *rest.Config
+}
@@ -0,0 +1,41 @@
+/*
+Copyright (c) 2019 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// noOpClient is a ClientInterface with no live API server behind it. It
+// backs offline overviews in place of a nil client, so a collaborator
+// that reaches for a client gets a consistent error instead of a panic.
+type noOpClient struct{}
+
+var _ ClientInterface = (*noOpClient)(nil)
+
+// NewNoOpClient creates a ClientInterface for offline use.
+func NewNoOpClient() ClientInterface {
+	return &noOpClient{}
+}
+
+func (c *noOpClient) KubernetesClient() (kubernetes.Interface, error) {
+	return nil, errors.New("no kubernetes client available in offline mode")
+}
+
+func (c *noOpClient) DiscoveryClient() (discovery.DiscoveryInterface, error) {
+	return nil, errors.New("no discovery client available in offline mode")
+}
+
+func (c *noOpClient) RESTClient() (rest.Interface, error) {
+	return nil, errors.New("no REST client available in offline mode")
+}
+
+func (c *noOpClient) RESTConfig() *rest.Config {
+	return nil
+}
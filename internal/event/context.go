@@ -21,22 +21,28 @@ import (
 type kubeContextsResponse struct {
 	Contexts       []kubeconfig.Context `json:"contexts"`
 	CurrentContext string               `json:"currentContext"`
+	ActiveContexts []string             `json:"activeContexts"`
 }
 
 type ContextGeneratorOption func(generator *ContextsGenerator)
 
 // ContextsGenerator generates kube contexts for the front end.
 type ContextsGenerator struct {
-	ConfigLoader kubeconfig.Loader
-	DashConfig   config.Dash
+	ConfigLoader   kubeconfig.Loader
+	DashConfig     config.Dash
+	ActiveContexts *kubeconfig.ContextSet
+
+	trigger chan struct{}
 }
 
 var _ octant.Generator = (*ContextsGenerator)(nil)
 
 func NewContextsGenerator(dashConfig config.Dash, options ...ContextGeneratorOption) *ContextsGenerator {
 	kcg := &ContextsGenerator{
-		ConfigLoader: kubeconfig.NewFSLoader(),
-		DashConfig:   dashConfig,
+		ConfigLoader:   kubeconfig.NewFSLoader(),
+		DashConfig:     dashConfig,
+		ActiveContexts: kubeconfig.NewContextSet(),
+		trigger:        make(chan struct{}, 1),
 	}
 
 	for _, option := range options {
@@ -46,6 +52,15 @@ func NewContextsGenerator(dashConfig config.Dash, options ...ContextGeneratorOpt
 	return kcg
 }
 
+// WithActiveContexts sets the set of contexts the generator reports as
+// active. Without this option, the generator reports only the dash
+// config's current context as active.
+func WithActiveContexts(contexts *kubeconfig.ContextSet) ContextGeneratorOption {
+	return func(generator *ContextsGenerator) {
+		generator.ActiveContexts = contexts
+	}
+}
+
 func (g *ContextsGenerator) Event(ctx context.Context) (octant.Event, error) {
 	kubeConfig, err := g.ConfigLoader.Load(g.DashConfig.KubeConfig())
 	if err != nil {
@@ -57,17 +72,28 @@ func (g *ContextsGenerator) Event(ctx context.Context) (octant.Event, error) {
 		currentContext = kubeConfig.CurrentContext
 	}
 
+	activeContexts := g.ActiveContexts.List()
+	if len(activeContexts) == 0 {
+		activeContexts = []string{currentContext}
+	}
+
 	resp := kubeContextsResponse{
 		CurrentContext: currentContext,
 		Contexts:       kubeConfig.Contexts,
+		ActiveContexts: activeContexts,
 	}
 
 	sort.Slice(resp.Contexts, func(i, j int) bool {
 		return resp.Contexts[i].Name < resp.Contexts[j].Name
 	})
 
+	eventType := octant.EventTypeKubeConfig
+	if len(activeContexts) > 1 {
+		eventType = octant.EventTypeMultiContext
+	}
+
 	e := octant.Event{
-		Type: octant.EventTypeKubeConfig,
+		Type: eventType,
 		Data: resp,
 	}
 
@@ -81,3 +107,21 @@ func (ContextsGenerator) ScheduleDelay() time.Duration {
 func (ContextsGenerator) Name() string {
 	return "kubeConfig"
 }
+
+// TriggerUpdate wakes the generator's next scheduled Event call early, so
+// callers that just mutated the kube config (switching, renaming, or
+// deleting a context, merging a new one in) don't have to wait out
+// ScheduleDelay for the front end to see the change.
+func (g *ContextsGenerator) TriggerUpdate() {
+	select {
+	case g.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Trigger returns the channel TriggerUpdate signals on. A scheduler can
+// select on it alongside its normal ScheduleDelay timer to call Event
+// immediately after a trigger.
+func (g *ContextsGenerator) Trigger() <-chan struct{} {
+	return g.trigger
+}
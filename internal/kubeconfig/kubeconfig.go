@@ -8,13 +8,16 @@ package kubeconfig
 import (
 	"path/filepath"
 	"sort"
+	"sync"
 
+	"github.com/pkg/errors"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/vmware-tanzu/octant/internal/util/strings"
 )
 
 //go:generate mockgen -destination=./fake/mock_loader.go -package=fake github.com/vmware-tanzu/octant/internal/kubeconfig Loader
+//go:generate mockgen -destination=./fake/mock_writer.go -package=fake github.com/vmware-tanzu/octant/internal/kubeconfig Writer
 
 // KubeConfig describes a kube config for dash.
 type KubeConfig struct {
@@ -27,12 +30,87 @@ type Context struct {
 	Name string `json:"name"`
 }
 
+// ContextSet tracks the set of contexts that are currently active. Unlike
+// CurrentContext, which names a single context, a ContextSet allows more
+// than one context to be active at the same time so callers can fan out
+// work across clusters.
+type ContextSet struct {
+	mu     sync.RWMutex
+	active map[string]bool
+}
+
+// NewContextSet creates a ContextSet active for the given context names.
+func NewContextSet(names ...string) *ContextSet {
+	cs := &ContextSet{
+		active: make(map[string]bool),
+	}
+
+	for _, name := range names {
+		cs.active[name] = true
+	}
+
+	return cs
+}
+
+// Activate adds name to the set of active contexts.
+func (cs *ContextSet) Activate(name string) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cs.active[name] = true
+}
+
+// Deactivate removes name from the set of active contexts.
+func (cs *ContextSet) Deactivate(name string) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	delete(cs.active, name)
+}
+
+// Contains returns true if name is currently active.
+func (cs *ContextSet) Contains(name string) bool {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	return cs.active[name]
+}
+
+// List returns the active context names in sorted order.
+func (cs *ContextSet) List() []string {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	list := make([]string, 0, len(cs.active))
+	for name := range cs.active {
+		list = append(list, name)
+	}
+
+	sort.Strings(list)
+
+	return list
+}
+
 // Loader is an interface for loading kube config.
 type Loader interface {
 	LoadFromFile(filename string) (*KubeConfig, error)
 	Load(content string) (*KubeConfig, error)
 }
 
+// Writer is an interface for persisting changes back to the kube config
+// files a Loader loaded from.
+type Writer interface {
+	// SwitchContext sets name as the current context.
+	SwitchContext(name string) error
+	// RenameContext renames a context from oldName to newName.
+	RenameContext(oldName, newName string) error
+	// DeleteContext removes a context.
+	DeleteContext(name string) error
+	// MergeKubeConfig merges the kube config described by content into the
+	// existing config files.
+	MergeKubeConfig(content string) error
+}
+
 // FSLoaderOpt is an option for configuring FSLoader.
 type FSLoaderOpt func(loader *FSLoader)
 
@@ -105,3 +183,138 @@ func (l *FSLoader) Load(content string) (*KubeConfig, error) {
 		CurrentContext: config.CurrentContext,
 	}, nil
 }
+
+// FSWriterOpt is an option for configuring FSWriter.
+type FSWriterOpt func(writer *FSWriter)
+
+// FSWriter persists kube config changes back to the precedence-chained
+// files a FSLoader loaded from.
+type FSWriter struct {
+	fileList string
+}
+
+var _ Writer = (*FSWriter)(nil)
+
+// NewFSWriter creates an instance of FSWriter. fileList is the same
+// `:`-separated (or OS list-separated) chain of file paths passed to
+// FSLoader.LoadFromFile.
+func NewFSWriter(fileList string, options ...FSWriterOpt) *FSWriter {
+	w := &FSWriter{fileList: fileList}
+
+	for _, option := range options {
+		option(w)
+	}
+
+	return w
+}
+
+func (w *FSWriter) loadingRules() *clientcmd.ClientConfigLoadingRules {
+	chain := strings.Deduplicate(filepath.SplitList(w.fileList))
+
+	return &clientcmd.ClientConfigLoadingRules{
+		Precedence: chain,
+	}
+}
+
+// SwitchContext sets name as the current context and persists the change.
+func (w *FSWriter) SwitchContext(name string) error {
+	loadingRules := w.loadingRules()
+
+	config, err := loadingRules.Load()
+	if err != nil {
+		return errors.Wrap(err, "load kube config")
+	}
+
+	if _, ok := config.Contexts[name]; !ok {
+		return errors.Errorf("context %q does not exist", name)
+	}
+
+	config.CurrentContext = name
+
+	return clientcmd.ModifyConfig(loadingRules, *config, true)
+}
+
+// RenameContext renames a context and persists the change.
+func (w *FSWriter) RenameContext(oldName, newName string) error {
+	loadingRules := w.loadingRules()
+
+	config, err := loadingRules.Load()
+	if err != nil {
+		return errors.Wrap(err, "load kube config")
+	}
+
+	context, ok := config.Contexts[oldName]
+	if !ok {
+		return errors.Errorf("context %q does not exist", oldName)
+	}
+
+	if _, ok := config.Contexts[newName]; ok {
+		return errors.Errorf("context %q already exists", newName)
+	}
+
+	config.Contexts[newName] = context
+	delete(config.Contexts, oldName)
+
+	if config.CurrentContext == oldName {
+		config.CurrentContext = newName
+	}
+
+	return clientcmd.ModifyConfig(loadingRules, *config, true)
+}
+
+// DeleteContext removes a context and persists the change.
+func (w *FSWriter) DeleteContext(name string) error {
+	loadingRules := w.loadingRules()
+
+	config, err := loadingRules.Load()
+	if err != nil {
+		return errors.Wrap(err, "load kube config")
+	}
+
+	if _, ok := config.Contexts[name]; !ok {
+		return errors.Errorf("context %q does not exist", name)
+	}
+
+	delete(config.Contexts, name)
+
+	if config.CurrentContext == name {
+		config.CurrentContext = ""
+	}
+
+	return clientcmd.ModifyConfig(loadingRules, *config, true)
+}
+
+// MergeKubeConfig merges the kube config described by content into the
+// current config file and persists the result.
+func (w *FSWriter) MergeKubeConfig(content string) error {
+	loadingRules := w.loadingRules()
+
+	existing, err := loadingRules.Load()
+	if err != nil {
+		return errors.Wrap(err, "load kube config")
+	}
+
+	cc, err := clientcmd.NewClientConfigFromBytes([]byte(content))
+	if err != nil {
+		return errors.Wrap(err, "parse kube config")
+	}
+
+	incoming, err := cc.RawConfig()
+	if err != nil {
+		return errors.Wrap(err, "read kube config")
+	}
+
+	for name, context := range incoming.Contexts {
+		existing.Contexts[name] = context
+	}
+
+	for name, cluster := range incoming.Clusters {
+		existing.Clusters[name] = cluster
+	}
+
+	for name, authInfo := range incoming.AuthInfos {
+		existing.AuthInfos[name] = authInfo
+	}
+
+	return clientcmd.ModifyConfig(loadingRules, *existing, true)
+}
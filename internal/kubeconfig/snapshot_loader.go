@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2019 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubeconfig
+
+import (
+	"io/ioutil"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// SnapshotLoader loads kube config contexts from a captured cluster
+// snapshot directory rather than a live kubeconfig file. Each top-level
+// directory under the snapshot path names one captured context, e.g.
+// snapshot/<context>/<kind>/<ns>/<name>.yaml, which lets a snapshot-backed
+// cache.Cache serve the same context names back to the describers.
+type SnapshotLoader struct {
+	path string
+}
+
+var _ Loader = (*SnapshotLoader)(nil)
+
+// NewSnapshotLoader creates an instance of SnapshotLoader rooted at path.
+func NewSnapshotLoader(path string) *SnapshotLoader {
+	return &SnapshotLoader{path: path}
+}
+
+// LoadFromFile ignores filename and loads contexts from the snapshot
+// directory configured on the loader, so SnapshotLoader can be used as a
+// drop-in replacement for FSLoader.
+func (l *SnapshotLoader) LoadFromFile(_ string) (*KubeConfig, error) {
+	return l.Load("")
+}
+
+// Load ignores content and returns the contexts captured in the snapshot
+// directory.
+func (l *SnapshotLoader) Load(_ string) (*KubeConfig, error) {
+	entries, err := ioutil.ReadDir(l.path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read snapshot directory %s", l.path)
+	}
+
+	var list []Context
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		list = append(list, Context{Name: entry.Name()})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	var current string
+	if len(list) > 0 {
+		current = list[0].Name
+	}
+
+	return &KubeConfig{
+		Contexts:       list,
+		CurrentContext: current,
+	}, nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright (c) 2019 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package octant holds the types background generators use to publish
+// updates dash streams to the front end over its event source.
+package octant
+
+import (
+	"context"
+	"time"
+)
+
+// EventType names a kind of Event a Generator can publish.
+type EventType string
+
+const (
+	// EventTypeKubeConfig reports the kube contexts available and which
+	// one is current.
+	EventTypeKubeConfig EventType = "kubeConfig"
+
+	// EventTypeMultiContext reports kube contexts when more than one is
+	// active at the same time.
+	EventTypeMultiContext EventType = "multiContext"
+
+	// EventTypeLogCollection reports the status of a context's active
+	// LogCollector.
+	EventTypeLogCollection EventType = "logCollection"
+
+	// EventTypeAnalysis reports how many analyzer findings of each
+	// severity are currently active.
+	EventTypeAnalysis EventType = "analysis"
+)
+
+// Event is a single update a Generator publishes for the front end.
+type Event struct {
+	Type EventType
+	Data interface{}
+	Err  error
+}
+
+// Generator periodically produces an Event for the front end to consume.
+type Generator interface {
+	// Event returns the generator's current update.
+	Event(ctx context.Context) (Event, error)
+	// ScheduleDelay is how long to wait before calling Event again.
+	ScheduleDelay() time.Duration
+	// Name uniquely identifies the generator.
+	Name() string
+}
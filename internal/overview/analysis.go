@@ -0,0 +1,122 @@
+package overview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/heptio/developer-dash/internal/analyzer"
+	"github.com/heptio/developer-dash/internal/api"
+	"github.com/heptio/developer-dash/internal/cache"
+	"github.com/heptio/developer-dash/internal/octant"
+	"github.com/heptio/developer-dash/internal/view/component"
+)
+
+// AnalysisGenerator runs the analyzer registry against the overview cache
+// on a schedule and reports how many findings of each severity are
+// currently active, so the navigation sidebar can badge affected
+// sections.
+type AnalysisGenerator struct {
+	cache    cache.Cache
+	registry *analyzer.Registry
+}
+
+var _ octant.Generator = (*AnalysisGenerator)(nil)
+
+// NewAnalysisGenerator creates an instance of AnalysisGenerator.
+func NewAnalysisGenerator(c cache.Cache, registry *analyzer.Registry) *AnalysisGenerator {
+	return &AnalysisGenerator{
+		cache:    c,
+		registry: registry,
+	}
+}
+
+type analysisResponse struct {
+	CountBySeverity map[analyzer.Severity]int `json:"countBySeverity"`
+}
+
+func (g *AnalysisGenerator) Event(ctx context.Context) (octant.Event, error) {
+	findings, err := g.registry.Run(ctx, g.cache, "")
+	if err != nil {
+		return octant.Event{}, err
+	}
+
+	counts := make(map[analyzer.Severity]int)
+	for _, finding := range findings {
+		counts[finding.Severity]++
+	}
+
+	return octant.Event{
+		Type: octant.EventTypeAnalysis,
+		Data: analysisResponse{CountBySeverity: counts},
+	}, nil
+}
+
+func (AnalysisGenerator) ScheduleDelay() time.Duration {
+	return 15 * time.Second
+}
+
+func (AnalysisGenerator) Name() string {
+	return "analysis"
+}
+
+// analysisTable renders findings as a component.Table, severity/kind/
+// object/reason/suggested-fix per row, so it can back the
+// /overview/analysis describer path.
+func analysisTable(findings []analyzer.Finding) *component.Table {
+	cols := component.NewTableCols("Severity", "Kind", "Object", "Reason", "Suggested Fix")
+	table := component.NewTable("Analysis", cols)
+
+	for _, finding := range findings {
+		object := finding.Name
+		if finding.Namespace != "" {
+			object = fmt.Sprintf("%s/%s", finding.Namespace, finding.Name)
+		}
+
+		table.Add(component.TableRow{
+			"Severity":      component.NewText(string(finding.Severity)),
+			"Kind":          component.NewText(finding.Kind),
+			"Object":        component.NewText(object),
+			"Reason":        component.NewText(finding.Reason),
+			"Suggested Fix": component.NewText(finding.SuggestedFix),
+		})
+	}
+
+	return table
+}
+
+// analysisHandler runs the analyzer registry and returns the results as a
+// component.Table.
+func (co *ClusterOverview) analysisHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if co.analyzerRegistry == nil {
+			co.logger.Errorf("analyzer registry is not configured")
+			http.Error(w, "analyzer registry is not configured", http.StatusInternalServerError)
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			api.RespondWithError(
+				w,
+				http.StatusNotFound,
+				fmt.Sprintf("unhandled HTTP method %s", r.Method),
+				co.logger,
+			)
+			return
+		}
+
+		namespace := r.URL.Query().Get("namespace")
+
+		findings, err := co.analyzerRegistry.Run(r.Context(), co.cache, namespace)
+		if err != nil {
+			co.logger.With("error", err).Errorf("run analyzers")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(analysisTable(findings)); err != nil {
+			co.logger.With("error", err).Errorf("encode analysis response")
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package overview
+
+import "github.com/spf13/pflag"
+
+// BindSnapshotPathFlag registers the --snapshot-path flag used to put
+// overview into offline mode (see Options.SnapshotPath), returning the
+// pointer flag.Parse populates.
+func BindSnapshotPathFlag(flags *pflag.FlagSet) *string {
+	return flags.String("snapshot-path", "", "load cluster data from a captured snapshot directory instead of a live cluster")
+}
@@ -0,0 +1,255 @@
+package overview
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/heptio/developer-dash/internal/log"
+	"github.com/heptio/developer-dash/internal/octant"
+)
+
+// PodLogOpener abstracts opening a streaming log for a single pod/container
+// so LogCollector does not need to know how the underlying client talks to
+// the API server.
+type PodLogOpener interface {
+	ListPods(ctx context.Context, namespace, selector string) ([]PodRef, error)
+	OpenPodLog(ctx context.Context, namespace, pod, container string, sinceTime time.Time) (io.ReadCloser, error)
+}
+
+// PodRef names a pod and the containers collection can target within it.
+type PodRef struct {
+	Namespace  string
+	Name       string
+	Containers []string
+}
+
+// LogCollectionSpec describes what a LogCollector should capture. Contexts
+// names which active contexts collection applies to; when it is non-empty,
+// a ClusterOverview only starts its own LogCollector if its context is
+// listed. An empty Contexts applies to every context.
+type LogCollectionSpec struct {
+	Contexts   []string  `json:"contexts"`
+	Namespaces []string  `json:"namespaces"`
+	Selector   string    `json:"selector"`
+	Since      time.Time `json:"since"`
+	MaxBytes   int64     `json:"maxBytes"`
+}
+
+// logCollectorStatus describes one active log stream for the frontend.
+type logCollectorStatus struct {
+	Namespace    string `json:"namespace"`
+	Pod          string `json:"pod"`
+	Container    string `json:"container"`
+	BytesWritten int64  `json:"bytesWritten"`
+}
+
+// LogCollector streams pod/container logs for a context to an on-disk
+// directory (<workDir>/logs/<context>/<namespace>/<pod>/<container>.log)
+// so users can retrieve them later for post-mortem debugging, the same way
+// an e2e test harness saves pod logs on failure. Each container has a
+// single log file that Start truncates and rewrites; files are not rotated,
+// and MaxBytes only caps how much of each stream is copied.
+type LogCollector struct {
+	workDir     string
+	contextName string
+	opener      PodLogOpener
+	logger      log.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	status map[string]*logCollectorStatus
+}
+
+var _ octant.Generator = (*LogCollector)(nil)
+
+// NewLogCollector creates an instance of LogCollector.
+func NewLogCollector(workDir, contextName string, opener PodLogOpener, logger log.Logger) *LogCollector {
+	return &LogCollector{
+		workDir:     workDir,
+		contextName: contextName,
+		opener:      opener,
+		logger:      logger,
+		status:      make(map[string]*logCollectorStatus),
+	}
+}
+
+// logDir returns the directory logs are written to for this context.
+func (c *LogCollector) logDir() string {
+	return filepath.Join(c.workDir, "logs", c.contextName)
+}
+
+// Start begins collecting logs matching spec, opening one streaming
+// request per pod/container that currently matches. Calling Start while a
+// collection is already running stops the previous one first.
+func (c *LogCollector) Start(ctx context.Context, spec LogCollectionSpec) error {
+	c.Stop()
+
+	c.mu.Lock()
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+	c.status = make(map[string]*logCollectorStatus)
+	c.mu.Unlock()
+
+	namespaces := spec.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	for _, namespace := range namespaces {
+		pods, err := c.opener.ListPods(ctx, namespace, spec.Selector)
+		if err != nil {
+			cancel()
+			return errors.Wrapf(err, "list pods in namespace %q", namespace)
+		}
+
+		for _, pod := range pods {
+			for _, container := range pod.Containers {
+				c.collect(ctx, pod.Namespace, pod.Name, container, spec.Since, spec.MaxBytes)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Stop cancels any in-flight log streams.
+func (c *LogCollector) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cancel != nil {
+		c.cancel()
+		c.cancel = nil
+	}
+}
+
+// collect opens the stream for a single container and copies it to disk in
+// the background, honoring ctx cancellation and an optional max byte cap.
+func (c *LogCollector) collect(ctx context.Context, namespace, pod, container string, since time.Time, maxBytes int64) {
+	key := namespace + "/" + pod + "/" + container
+
+	c.mu.Lock()
+	c.status[key] = &logCollectorStatus{Namespace: namespace, Pod: pod, Container: container}
+	c.mu.Unlock()
+
+	go func() {
+		dir := filepath.Join(c.logDir(), namespace, pod)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			c.logger.With("error", err).Errorf("create log directory %s", dir)
+			return
+		}
+
+		file, err := os.Create(filepath.Join(dir, container+".log"))
+		if err != nil {
+			c.logger.With("error", err).Errorf("create log file for %s", key)
+			return
+		}
+		defer file.Close()
+
+		stream, err := c.opener.OpenPodLog(ctx, namespace, pod, container, since)
+		if err != nil {
+			c.logger.With("error", err).Errorf("open log stream for %s", key)
+			return
+		}
+		defer stream.Close()
+
+		src := io.Reader(stream)
+		if maxBytes > 0 {
+			src = io.LimitReader(stream, maxBytes)
+		}
+
+		written, err := io.Copy(file, src)
+		if err != nil && ctx.Err() == nil {
+			c.logger.With("error", err).Errorf("collect log for %s", key)
+		}
+
+		c.mu.Lock()
+		if s, ok := c.status[key]; ok {
+			s.BytesWritten = written
+		}
+		c.mu.Unlock()
+	}()
+}
+
+// Archive writes a tar.gz of every log file captured for this context to w.
+func (c *LogCollector) Archive(w io.Writer) error {
+	gzw := gzip.NewWriter(w)
+	defer gzw.Close()
+
+	tw := tar.NewWriter(gzw)
+	defer tw.Close()
+
+	root := c.logDir()
+
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = rel
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+// Event implements octant.Generator, reporting the set of active
+// collectors so the frontend can render their status.
+func (c *LogCollector) Event(ctx context.Context) (octant.Event, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	statuses := make([]*logCollectorStatus, 0, len(c.status))
+	for _, status := range c.status {
+		statuses = append(statuses, status)
+	}
+
+	return octant.Event{
+		Type: octant.EventTypeLogCollection,
+		Data: statuses,
+	}, nil
+}
+
+// ScheduleDelay implements octant.Generator.
+func (c *LogCollector) ScheduleDelay() time.Duration {
+	return 5 * time.Second
+}
+
+// Name implements octant.Generator.
+func (c *LogCollector) Name() string {
+	return "logCollector"
+}
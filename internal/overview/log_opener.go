@@ -0,0 +1,73 @@
+package overview
+
+import (
+	"context"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/heptio/developer-dash/internal/cluster"
+)
+
+// clusterPodLogOpener implements PodLogOpener against a live cluster
+// client, listing pods through the typed clientset and opening a
+// streaming log request for each container.
+type clusterPodLogOpener struct {
+	client cluster.ClientInterface
+}
+
+var _ PodLogOpener = (*clusterPodLogOpener)(nil)
+
+// NewClusterPodLogOpener creates a PodLogOpener backed by client.
+func NewClusterPodLogOpener(client cluster.ClientInterface) PodLogOpener {
+	return &clusterPodLogOpener{client: client}
+}
+
+// ListPods lists the pods matching selector in namespace, along with their
+// container names.
+func (o *clusterPodLogOpener) ListPods(ctx context.Context, namespace, selector string) ([]PodRef, error) {
+	clientset, err := o.client.KubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+
+	podList, err := clientset.CoreV1().Pods(namespace).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]PodRef, 0, len(podList.Items))
+	for _, pod := range podList.Items {
+		containers := make([]string, 0, len(pod.Spec.Containers))
+		for _, c := range pod.Spec.Containers {
+			containers = append(containers, c.Name)
+		}
+
+		refs = append(refs, PodRef{
+			Namespace:  pod.Namespace,
+			Name:       pod.Name,
+			Containers: containers,
+		})
+	}
+
+	return refs, nil
+}
+
+// OpenPodLog opens a streaming log request for pod/container, starting at
+// sinceTime if it is non-zero.
+func (o *clusterPodLogOpener) OpenPodLog(ctx context.Context, namespace, pod, container string, sinceTime time.Time) (io.ReadCloser, error) {
+	clientset, err := o.client.KubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &corev1.PodLogOptions{Container: container}
+	if !sinceTime.IsZero() {
+		t := metav1.NewTime(sinceTime)
+		opts.SinceTime = &t
+	}
+
+	return clientset.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream()
+}
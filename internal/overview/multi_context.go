@@ -0,0 +1,129 @@
+package overview
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/heptio/developer-dash/internal/event"
+	"github.com/heptio/developer-dash/internal/kubeconfig"
+	"github.com/heptio/developer-dash/internal/module"
+	"github.com/heptio/developer-dash/internal/view/component"
+	"github.com/heptio/developer-dash/pkg/plugin"
+)
+
+// ClusterOverviewFactory creates the Options needed to build a
+// ClusterOverview for a given context. Callers supply this so
+// MultiContextOverview does not need to know how to dial a cluster.
+type ClusterOverviewFactory func(ctx context.Context, contextName string) (Options, error)
+
+// MultiContextOverview fans requests for cluster overview content out
+// across simultaneously active kube contexts. It keeps one ClusterOverview
+// per context, all sharing a single plugin manager, so plugins only need
+// to be loaded once regardless of how many clusters are active.
+type MultiContextOverview struct {
+	mu            sync.RWMutex
+	overviews     map[string]*ClusterOverview
+	activeSet     *kubeconfig.ContextSet
+	pluginManager *plugin.Manager
+	factory       ClusterOverviewFactory
+}
+
+// NewMultiContextOverview creates an instance of MultiContextOverview. When
+// contextsGenerator is non-nil, its ActiveContexts is pointed at the same
+// ContextSet this registry tracks, so a ContextsGenerator.Event() call
+// reports exactly the contexts this registry currently has active.
+func NewMultiContextOverview(pluginManager *plugin.Manager, contextsGenerator *event.ContextsGenerator, factory ClusterOverviewFactory) *MultiContextOverview {
+	activeSet := kubeconfig.NewContextSet()
+
+	if contextsGenerator != nil {
+		contextsGenerator.ActiveContexts = activeSet
+	}
+
+	return &MultiContextOverview{
+		overviews:     make(map[string]*ClusterOverview),
+		activeSet:     activeSet,
+		pluginManager: pluginManager,
+		factory:       factory,
+	}
+}
+
+// Register adds an already-constructed ClusterOverview for contextName and
+// marks the context active, without going through factory. NewClusterOverview
+// calls this when Options.MultiContextRegistry and Options.ContextName are
+// set, so every overview it
+// builds is reflected in the registry's ActiveSet (and therefore in any
+// ContextsGenerator sharing that set) as soon as it comes up.
+func (m *MultiContextOverview) Register(contextName string, co *ClusterOverview) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.overviews[contextName] = co
+	m.activeSet.Activate(contextName)
+}
+
+// ActiveSet returns the ContextSet tracking which contexts are active. It
+// can be shared with a ContextsGenerator so `kubeContextsResponse` reports
+// the same set of active contexts this registry is serving.
+func (m *MultiContextOverview) ActiveSet() *kubeconfig.ContextSet {
+	return m.activeSet
+}
+
+// Activate brings up a ClusterOverview for contextName if one isn't
+// already running. It is a no-op if the context is already active.
+func (m *MultiContextOverview) Activate(ctx context.Context, contextName string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.overviews[contextName]; ok {
+		return nil
+	}
+
+	options, err := m.factory(ctx, contextName)
+	if err != nil {
+		return errors.Wrapf(err, "build overview options for context %q", contextName)
+	}
+
+	options.PluginManager = m.pluginManager
+
+	co, err := NewClusterOverview(ctx, options)
+	if err != nil {
+		return errors.Wrapf(err, "create cluster overview for context %q", contextName)
+	}
+
+	m.overviews[contextName] = co
+	m.activeSet.Activate(contextName)
+
+	return nil
+}
+
+// Deactivate stops and removes the ClusterOverview for contextName.
+func (m *MultiContextOverview) Deactivate(contextName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if co, ok := m.overviews[contextName]; ok {
+		co.Stop()
+		delete(m.overviews, contextName)
+		m.activeSet.Deactivate(contextName)
+	}
+}
+
+// ActiveContexts returns the names of contexts currently active.
+func (m *MultiContextOverview) ActiveContexts() []string {
+	return m.activeSet.List()
+}
+
+// Content serves content for a specific active context.
+func (m *MultiContextOverview) Content(ctx context.Context, contextName, contentPath, prefix, namespace string, opts module.ContentOptions) (component.ContentResponse, error) {
+	m.mu.RLock()
+	co, ok := m.overviews[contextName]
+	m.mu.RUnlock()
+
+	if !ok {
+		return component.ContentResponse{}, errors.Errorf("context %q is not active", contextName)
+	}
+
+	return co.Content(ctx, contentPath, prefix, namespace, opts)
+}
@@ -2,16 +2,25 @@ package overview
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
 
 	"github.com/gorilla/mux"
+	"github.com/heptio/developer-dash/internal/analyzer"
 	"github.com/heptio/developer-dash/internal/api"
+	"github.com/heptio/developer-dash/internal/event"
+	"github.com/heptio/developer-dash/internal/kubeconfig"
 	"github.com/heptio/developer-dash/internal/module"
+	"github.com/heptio/developer-dash/internal/octant"
 	"github.com/heptio/developer-dash/internal/portforward"
 	"github.com/heptio/developer-dash/pkg/plugin"
 
@@ -25,26 +34,64 @@ import (
 )
 
 type Options struct {
-	Client        cluster.ClientInterface
-	Cache         cache.Cache
-	Namespace     string
-	Logger        log.Logger
-	PluginManager *plugin.Manager
+	Client           cluster.ClientInterface
+	Cache            cache.Cache
+	Namespace        string
+	Logger           log.Logger
+	PluginManager    *plugin.Manager
+	KubeConfigWriter kubeconfig.Writer
+	// KubeConfigPath is the precedence-chained kube config file list to
+	// write context changes back to. It is only used to build a default
+	// KubeConfigWriter when one isn't supplied directly.
+	KubeConfigPath string
+	// ContextsGenerator, when set, is triggered to refresh immediately
+	// after a kube config mutation succeeds, rather than waiting out its
+	// own ScheduleDelay for the front end to see the change.
+	ContextsGenerator *event.ContextsGenerator
+	// MultiContextRegistry, when set, has this overview registered with it
+	// under ContextName once construction succeeds, so a
+	// MultiContextOverview fanning requests across several active contexts
+	// knows about this one too.
+	MultiContextRegistry *MultiContextOverview
+	// SnapshotPath, when set, puts overview into offline mode: discovery,
+	// informers, and port forwarding are replaced with no-op/read-only
+	// equivalents backed by a previously captured cluster snapshot rather
+	// than a live cluster, and Cache is expected to already be backed by
+	// that snapshot.
+	SnapshotPath string
+	// ContextName is the kube context this overview is generating content
+	// for. It is used to namespace captured logs on disk.
+	ContextName string
+	// WorkDir is the directory LogCollector roots its captured logs under.
+	WorkDir string
+	// PodLogOpener streams pod/container logs for LogCollector. When it is
+	// nil, a live overview builds one from Client; an offline overview has
+	// none, and its log collection handlers then respond with an error.
+	PodLogOpener PodLogOpener
 }
 
 // ClusterOverview is an API for generating a cluster overview.
 type ClusterOverview struct {
-	client         cluster.ClientInterface
-	logger         log.Logger
-	cache          cache.Cache
-	generator      *realGenerator
-	portForwardSvc portforward.PortForwarder
-	pluginManager  *plugin.Manager
+	client            cluster.ClientInterface
+	logger            log.Logger
+	cache             cache.Cache
+	generator         *realGenerator
+	portForwardSvc    portforward.PortForwarder
+	pluginManager     *plugin.Manager
+	kubeConfigWriter  kubeconfig.Writer
+	contextsGenerator *event.ContextsGenerator
+	logCollector      *LogCollector
+	analyzerRegistry  *analyzer.Registry
+	analysisGenerator *AnalysisGenerator
+	snapshotWriter    *SnapshotWriter
+	contextName       string
 }
 
 // NewClusterOverview creates an instance of ClusterOverview.
 func NewClusterOverview(ctx context.Context, options Options) (*ClusterOverview, error) {
-	if options.Client == nil {
+	offline := options.SnapshotPath != ""
+
+	if options.Client == nil && !offline {
 		return nil, errors.New("nil cluster client")
 	}
 
@@ -52,9 +99,30 @@ func NewClusterOverview(ctx context.Context, options Options) (*ClusterOverview,
 		return nil, errors.New("plugin manager is nil")
 	}
 
-	di, err := options.Client.DiscoveryClient()
-	if err != nil {
-		return nil, errors.Wrapf(err, "creating DiscoveryClient")
+	if offline && options.Cache == nil {
+		options.Cache = cache.NewSnapshotCache(options.SnapshotPath, options.ContextName)
+	}
+
+	if offline && options.Client == nil {
+		// A nil client would panic the first time something downstream
+		// reaches for one; a no-op client fails those calls explicitly
+		// instead.
+		options.Client = cluster.NewNoOpClient()
+	}
+
+	// DiscoveryClient talks to a live API server; offline mode has no
+	// server to discover against, so di is a FakeDiscovery reporting an
+	// empty API surface rather than nil, which would panic the first time
+	// the generator resolves a resource.
+	var di discovery.DiscoveryInterface
+	if !offline {
+		var err error
+		di, err = options.Client.DiscoveryClient()
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating DiscoveryClient")
+		}
+	} else {
+		di = &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
 	}
 
 	pm := newPathMatcher()
@@ -83,43 +151,123 @@ func NewClusterOverview(ctx context.Context, options Options) (*ClusterOverview,
 		}
 	}(pm, customResourcesDescriber)
 
-	go watchCRDs(ctx, options.Cache, crdAddFunc, crdDeleteFunc)
+	if !offline {
+		// CRDs can only be watched against a live cluster; a snapshot is a
+		// static point-in-time dump with nothing to watch.
+		go watchCRDs(ctx, options.Cache, crdAddFunc, crdDeleteFunc)
+	}
 
-	// Port Forwarding
-	restClient, err := options.Client.RESTClient()
-	if err != nil {
-		return nil, errors.Wrap(err, "fetching RESTClient")
-	}
-	pfOpts := portforward.ServiceOptions{
-		RESTClient: restClient,
-		Config:     options.Client.RESTConfig(),
-		Cache:      options.Cache,
-		PortForwarder: &portforward.DefaultPortForwarder{
-			IOStreams: portforward.IOStreams{
-				In:     os.Stdin,
-				Out:    os.Stdout,
-				ErrOut: os.Stderr,
+	// analyzerRegistry holds the built-in analyzers. It is kept only on the
+	// ClusterOverview; plugin-provided analyzers are not registered with it.
+	analyzerRegistry := analyzer.NewDefaultRegistry()
+	analysisGenerator := NewAnalysisGenerator(options.Cache, analyzerRegistry)
+
+	// Port Forwarding is a live-cluster feature; snapshots have no running
+	// pods to forward to, so offline mode leaves pfSvc nil. Handlers that
+	// depend on it already reject requests when it is nil.
+	var pfSvc portforward.PortForwarder
+	if !offline {
+		restClient, err := options.Client.RESTClient()
+		if err != nil {
+			return nil, errors.Wrap(err, "fetching RESTClient")
+		}
+		pfOpts := portforward.ServiceOptions{
+			RESTClient: restClient,
+			Config:     options.Client.RESTConfig(),
+			Cache:      options.Cache,
+			PortForwarder: &portforward.DefaultPortForwarder{
+				IOStreams: portforward.IOStreams{
+					In:     os.Stdin,
+					Out:    os.Stdout,
+					ErrOut: os.Stderr,
+				},
 			},
-		},
+		}
+		pfSvc = portforward.New(ctx, pfOpts, options.Logger)
 	}
-	pfSvc := portforward.New(ctx, pfOpts, options.Logger)
 
 	g, err := newGenerator(options.Cache, di, pm, options.Client, pfSvc)
 	if err != nil {
 		return nil, errors.Wrap(err, "create overview generator")
 	}
 
+	podLogOpener := options.PodLogOpener
+	if podLogOpener == nil && !offline && options.Client != nil {
+		podLogOpener = NewClusterPodLogOpener(options.Client)
+	}
+
+	var logCollector *LogCollector
+	if podLogOpener != nil {
+		logCollector = NewLogCollector(options.WorkDir, options.ContextName, podLogOpener, options.Logger)
+	}
+
+	kubeConfigWriter := options.KubeConfigWriter
+	if kubeConfigWriter == nil && options.KubeConfigPath != "" {
+		kubeConfigWriter = kubeconfig.NewFSWriter(options.KubeConfigPath)
+	}
+
+	if offline && options.ContextsGenerator != nil {
+		options.ContextsGenerator.ConfigLoader = kubeconfig.NewSnapshotLoader(options.SnapshotPath)
+	}
+
+	// snapshotWriter dumps whatever this overview's cache currently holds
+	// to disk, so a live cluster can be captured for later offline triage
+	// with a SnapshotLoader/SnapshotCache.
+	var snapshotWriter *SnapshotWriter
+	if options.WorkDir != "" {
+		snapshotWriter = NewSnapshotWriter(options.WorkDir, func(ctx context.Context) ([]*unstructured.Unstructured, error) {
+			return options.Cache.List(ctx, cache.Key{})
+		}, options.Logger)
+	}
+
 	co := &ClusterOverview{
-		client:         options.Client,
-		logger:         options.Logger,
-		cache:          options.Cache,
-		generator:      g,
-		portForwardSvc: pfSvc,
-		pluginManager:  options.PluginManager,
+		client:            options.Client,
+		logger:            options.Logger,
+		cache:             options.Cache,
+		generator:         g,
+		portForwardSvc:    pfSvc,
+		pluginManager:     options.PluginManager,
+		kubeConfigWriter:  kubeConfigWriter,
+		contextsGenerator: options.ContextsGenerator,
+		logCollector:      logCollector,
+		analyzerRegistry:  analyzerRegistry,
+		analysisGenerator: analysisGenerator,
+		snapshotWriter:    snapshotWriter,
+		contextName:       options.ContextName,
 	}
+
+	if options.MultiContextRegistry != nil && options.ContextName != "" {
+		options.MultiContextRegistry.Register(options.ContextName, co)
+	}
+
 	return co, nil
 }
 
+// refreshKubeConfig triggers an immediate ContextsGenerator event after a
+// kube config mutation, if one is configured.
+func (co *ClusterOverview) refreshKubeConfig() {
+	if co.contextsGenerator != nil {
+		co.contextsGenerator.TriggerUpdate()
+	}
+}
+
+// Generators returns the octant.Generators this overview owns, so whatever
+// schedules them can call Event() on each at its own ScheduleDelay rather
+// than only on-demand through an HTTP handler.
+func (co *ClusterOverview) Generators() []octant.Generator {
+	var generators []octant.Generator
+
+	if co.logCollector != nil {
+		generators = append(generators, co.logCollector)
+	}
+
+	if co.analysisGenerator != nil {
+		generators = append(generators, co.analysisGenerator)
+	}
+
+	return generators
+}
+
 // Name returns the name for this module.
 func (co *ClusterOverview) Name() string {
 	return "overview"
@@ -178,6 +326,237 @@ func (co *ClusterOverview) Handlers(ctx context.Context) map[string]http.Handler
 		"/logs/pod/{pod}/container/{container}": containerLogsHandler(co.client),
 		"/port-forwards":                        co.portForwardsHandler(),
 		"/port-forwards/{id}":                   co.portForwardHandler(),
+		"/kube-config/contexts/{name}":          co.kubeConfigContextHandler(),
+		"/kube-config/merge":                    co.kubeConfigMergeHandler(),
+		"/logs/collect":                         co.logCollectHandler(),
+		"/logs/archive/{context}":               co.logArchiveHandler(),
+		"/overview/analysis":                    co.analysisHandler(),
+		"/snapshot/dump/{context}":              co.snapshotDumpHandler(),
+	}
+}
+
+// snapshotDumpHandler writes the current cache's contents to disk under
+// WorkDir, for later offline triage via SnapshotLoader/SnapshotCache.
+func (co *ClusterOverview) snapshotDumpHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if co.snapshotWriter == nil {
+			co.logger.Errorf("snapshot writer is not configured")
+			http.Error(w, "snapshot writer is not configured", http.StatusInternalServerError)
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			api.RespondWithError(
+				w,
+				http.StatusNotFound,
+				fmt.Sprintf("unhandled HTTP method %s", r.Method),
+				co.logger,
+			)
+			return
+		}
+
+		vars := mux.Vars(r)
+
+		if err := co.snapshotWriter.Dump(r.Context(), vars["context"]); err != nil {
+			api.RespondWithError(w, http.StatusInternalServerError, err.Error(), co.logger)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// logCollectHandler starts or stops a LogCollector for this context.
+func (co *ClusterOverview) logCollectHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if co.logCollector == nil {
+			co.logger.Errorf("log collector is not configured")
+			http.Error(w, "log collector is not configured", http.StatusInternalServerError)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			defer r.Body.Close()
+
+			var spec LogCollectionSpec
+			if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+				api.RespondWithError(w, http.StatusBadRequest, err.Error(), co.logger)
+				return
+			}
+
+			// Contexts scopes the request to a subset of active contexts.
+			// If it's set and this context isn't in it, every other active
+			// context's handler received the same request and only the
+			// ones actually listed should start collecting.
+			if len(spec.Contexts) > 0 && co.contextName != "" && !containsString(spec.Contexts, co.contextName) {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			if err := co.logCollector.Start(r.Context(), spec); err != nil {
+				api.RespondWithError(w, http.StatusInternalServerError, err.Error(), co.logger)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		case http.MethodDelete:
+			co.logCollector.Stop()
+			w.WriteHeader(http.StatusOK)
+		default:
+			api.RespondWithError(
+				w,
+				http.StatusNotFound,
+				fmt.Sprintf("unhandled HTTP method %s", r.Method),
+				co.logger,
+			)
+		}
+	}
+}
+
+// logArchiveHandler returns a tar.gz of the logs this context's
+// LogCollector has captured so far.
+func (co *ClusterOverview) logArchiveHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if co.logCollector == nil {
+			co.logger.Errorf("log collector is not configured")
+			http.Error(w, "log collector is not configured", http.StatusInternalServerError)
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			api.RespondWithError(
+				w,
+				http.StatusNotFound,
+				fmt.Sprintf("unhandled HTTP method %s", r.Method),
+				co.logger,
+			)
+			return
+		}
+
+		vars := mux.Vars(r)
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s-logs.tar.gz", vars["context"]))
+
+		if err := co.logCollector.Archive(w); err != nil {
+			co.logger.With("error", err).Errorf("archive logs")
+		}
+	}
+}
+
+// switchContextRequest renames or activates the named context, depending on
+// which fields are set.
+type switchContextRequest struct {
+	NewName string `json:"newName"`
+}
+
+type mergeKubeConfigRequest struct {
+	KubeConfig string `json:"kubeConfig"`
+}
+
+// kubeConfigContextHandler handles switching, renaming, and deleting a
+// single kube config context.
+func (co *ClusterOverview) kubeConfigContextHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if co.kubeConfigWriter == nil {
+			co.logger.Errorf("kube config writer is nil")
+			http.Error(w, "kube config writer is nil", http.StatusInternalServerError)
+			return
+		}
+
+		vars := mux.Vars(r)
+		name := vars["name"]
+
+		switch r.Method {
+		case http.MethodPut:
+			defer r.Body.Close()
+
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				api.RespondWithError(w, http.StatusBadRequest, err.Error(), co.logger)
+				return
+			}
+
+			var req switchContextRequest
+			if len(body) > 0 {
+				if err := json.Unmarshal(body, &req); err != nil {
+					api.RespondWithError(w, http.StatusBadRequest, err.Error(), co.logger)
+					return
+				}
+			}
+
+			if req.NewName == "" {
+				if err := co.kubeConfigWriter.SwitchContext(name); err != nil {
+					api.RespondWithError(w, http.StatusInternalServerError, err.Error(), co.logger)
+					return
+				}
+
+				co.refreshKubeConfig()
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			if err := co.kubeConfigWriter.RenameContext(name, req.NewName); err != nil {
+				api.RespondWithError(w, http.StatusInternalServerError, err.Error(), co.logger)
+				return
+			}
+
+			co.refreshKubeConfig()
+			w.WriteHeader(http.StatusOK)
+		case http.MethodDelete:
+			if err := co.kubeConfigWriter.DeleteContext(name); err != nil {
+				api.RespondWithError(w, http.StatusInternalServerError, err.Error(), co.logger)
+				return
+			}
+
+			co.refreshKubeConfig()
+			w.WriteHeader(http.StatusOK)
+		default:
+			api.RespondWithError(
+				w,
+				http.StatusNotFound,
+				fmt.Sprintf("unhandled HTTP method %s", r.Method),
+				co.logger,
+			)
+		}
+	}
+}
+
+// kubeConfigMergeHandler handles merging an uploaded kube config into the
+// current one.
+func (co *ClusterOverview) kubeConfigMergeHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if co.kubeConfigWriter == nil {
+			co.logger.Errorf("kube config writer is nil")
+			http.Error(w, "kube config writer is nil", http.StatusInternalServerError)
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			api.RespondWithError(
+				w,
+				http.StatusNotFound,
+				fmt.Sprintf("unhandled HTTP method %s", r.Method),
+				co.logger,
+			)
+			return
+		}
+
+		defer r.Body.Close()
+
+		var req mergeKubeConfigRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, err.Error(), co.logger)
+			return
+		}
+
+		if err := co.kubeConfigWriter.MergeKubeConfig(req.KubeConfig); err != nil {
+			api.RespondWithError(w, http.StatusInternalServerError, err.Error(), co.logger)
+			return
+		}
+
+		co.refreshKubeConfig()
+		w.WriteHeader(http.StatusOK)
 	}
 }
 
@@ -237,3 +616,13 @@ func (co *ClusterOverview) portForwardHandler() http.HandlerFunc {
 		}
 	}
 }
+
+// containsString reports whether values contains s.
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package overview
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/heptio/developer-dash/internal/log"
+)
+
+// SnapshotObjectLister returns every object a SnapshotWriter should persist.
+// Callers implement this by walking a live cache.Cache.
+type SnapshotObjectLister func(ctx context.Context) ([]*unstructured.Unstructured, error)
+
+// SnapshotWriter dumps cluster objects to a directory tree that a
+// kubeconfig.SnapshotLoader and a snapshot-backed cache can later read
+// back, so a cluster can be triaged offline.
+type SnapshotWriter struct {
+	path   string
+	lister SnapshotObjectLister
+	logger log.Logger
+}
+
+// NewSnapshotWriter creates an instance of SnapshotWriter rooted at path.
+func NewSnapshotWriter(path string, lister SnapshotObjectLister, logger log.Logger) *SnapshotWriter {
+	return &SnapshotWriter{
+		path:   path,
+		lister: lister,
+		logger: logger,
+	}
+}
+
+// Dump walks the configured lister and writes each object to
+// <path>/<contextName>/<kind>/<namespace>/<name>.yaml.
+func (w *SnapshotWriter) Dump(ctx context.Context, contextName string) error {
+	objects, err := w.lister(ctx)
+	if err != nil {
+		return errors.Wrap(err, "list objects for snapshot")
+	}
+
+	for _, object := range objects {
+		kind := object.GetKind()
+		dir := filepath.Join(w.path, contextName, kind, object.GetNamespace())
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return errors.Wrapf(err, "create snapshot directory %s", dir)
+		}
+
+		data, err := yaml.Marshal(object.Object)
+		if err != nil {
+			return errors.Wrapf(err, "marshal object %s/%s", object.GetNamespace(), object.GetName())
+		}
+
+		file := filepath.Join(dir, object.GetName()+".yaml")
+		if err := ioutil.WriteFile(file, data, 0644); err != nil {
+			return errors.Wrapf(err, "write snapshot file %s", file)
+		}
+	}
+
+	w.logger.With("context", contextName, "count", len(objects)).Debugf("wrote cluster snapshot")
+
+	return nil
+}
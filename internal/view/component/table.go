@@ -2,20 +2,79 @@ package component
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // TableConfig is the contents of a Table
 type TableConfig struct {
-	Columns      []TableCol `json:"columns"`
-	Rows         []TableRow `json:"rows"`
-	EmptyContent string     `json:"emptyContent"`
+	Columns      []TableCol    `json:"columns"`
+	Rows         []TableRow    `json:"rows"`
+	EmptyContent string        `json:"emptyContent"`
+	Sort         *TableSort    `json:"sort,omitempty"`
+	Filters      []TableFilter `json:"filters,omitempty"`
+	Pagination   *Pagination   `json:"pagination,omitempty"`
 }
 
+// ColType describes the data type a TableCol holds, so consumers don't
+// have to sniff cell values to sort or filter a column correctly.
+type ColType string
+
+const (
+	ColTypeString    ColType = "string"
+	ColTypeInt       ColType = "int"
+	ColTypeTimestamp ColType = "timestamp"
+	ColTypeQuantity  ColType = "quantity"
+	ColTypeDuration  ColType = "duration"
+	ColTypeStatus    ColType = "status"
+)
+
 // TableCol describes a column from a table. Accessor is the key this
 // column will appear as in table rows, and must be unique within a table.
 type TableCol struct {
-	Name     string `json:"name"`
-	Accessor string `json:"accessor"`
+	Name       string  `json:"name"`
+	Accessor   string  `json:"accessor"`
+	Type       ColType `json:"type,omitempty"`
+	Sortable   bool    `json:"sortable,omitempty"`
+	Filterable bool    `json:"filterable,omitempty"`
+	Width      int     `json:"width,omitempty"`
+}
+
+// TableSort describes the column a table is currently sorted by.
+type TableSort struct {
+	Column     string `json:"column"`
+	Descending bool   `json:"descending"`
+}
+
+// FilterOp is a comparison operator a TableFilter applies to a column.
+type FilterOp string
+
+const (
+	FilterOpEquals      FilterOp = "eq"
+	FilterOpContains    FilterOp = "contains"
+	FilterOpGreaterThan FilterOp = "gt"
+	FilterOpLessThan    FilterOp = "lt"
+)
+
+// TableFilter describes a single predicate applied to a column.
+type TableFilter struct {
+	Column string   `json:"column"`
+	Op     FilterOp `json:"op"`
+	Value  string   `json:"value"`
+}
+
+// Pagination describes which page of rows a table is showing.
+type Pagination struct {
+	Page     int `json:"page"`
+	PageSize int `json:"pageSize"`
+	Total    int `json:"total"`
 }
 
 // TableRow is a row in table. Each key->value represents a particular column in the row.
@@ -24,19 +83,28 @@ type TableRow map[string]ViewComponent
 func (t *TableRow) UnmarshalJSON(data []byte) error {
 	*t = make(TableRow)
 
-	x := map[string]TypedObject{}
-
-	if err := json.Unmarshal(data, &x); err != nil {
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
 
-	for k, v := range x {
-		vc, err := v.ToViewComponent()
-		if err != nil {
-			return err
+	for k, v := range raw {
+		var typed TypedObject
+		if err := json.Unmarshal(v, &typed); err == nil {
+			if vc, err := typed.ToViewComponent(); err == nil {
+				(*t)[k] = vc
+				continue
+			}
 		}
 
-		(*t)[k] = vc
+		// A cell with no component envelope is a bare scalar matching one
+		// of TableCol's Type hints (int/timestamp/quantity/...); render it
+		// as plain text instead of failing the whole row's decode.
+		var scalar interface{}
+		if err := json.Unmarshal(v, &scalar); err != nil {
+			return err
+		}
+		(*t)[k] = NewText(fmt.Sprintf("%v", scalar))
 	}
 
 	return nil
@@ -99,6 +167,293 @@ func (t *Table) AddColumn(name string) {
 	})
 }
 
+// SetSort sorts the table's rows by column, reordering Config.Rows in
+// place and recording the sort on Config.Sort so clients know it was
+// applied server-side. Rows are compared according to the column's Type,
+// so e.g. a quantity column sorts numerically rather than lexically.
+func (t *Table) SetSort(column string, descending bool) {
+	colType := t.colType(column)
+	rows := t.Config.Rows
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		less := compareByType(cellString(rows[i][column]), cellString(rows[j][column]), colType)
+		if descending {
+			return !less
+		}
+		return less
+	})
+
+	t.Config.Sort = &TableSort{Column: column, Descending: descending}
+}
+
+// AddFilter appends a filter and removes rows that do not match it.
+func (t *Table) AddFilter(filter TableFilter) {
+	t.Config.Filters = append(t.Config.Filters, filter)
+	colType := t.colType(filter.Column)
+
+	var kept []TableRow
+	for _, row := range t.Config.Rows {
+		if rowMatchesFilter(row, filter, colType) {
+			kept = append(kept, row)
+		}
+	}
+	t.Config.Rows = kept
+}
+
+// colType returns the Type configured for accessor, defaulting to
+// ColTypeString if the column isn't found or doesn't set one.
+func (t *Table) colType(accessor string) ColType {
+	for _, col := range t.Config.Columns {
+		if col.Accessor == accessor {
+			if col.Type != "" {
+				return col.Type
+			}
+			break
+		}
+	}
+
+	return ColTypeString
+}
+
+// Paginate narrows Config.Rows down to a single page and records the
+// resulting Pagination, including the pre-paginate row count as Total. A
+// negative page or pageSize is treated as 0 rather than producing an
+// invalid slice range.
+func (t *Table) Paginate(page, pageSize int) {
+	if page < 0 {
+		page = 0
+	}
+	if pageSize < 0 {
+		pageSize = 0
+	}
+
+	total := len(t.Config.Rows)
+
+	start := page * pageSize
+	if start < 0 || start > total {
+		start = total
+	}
+
+	end := start + pageSize
+	if end < start || end > total {
+		end = total
+	}
+
+	t.Config.Rows = t.Config.Rows[start:end]
+	t.Config.Pagination = &Pagination{Page: page, PageSize: pageSize, Total: total}
+}
+
+// cellValueEnvelope unwraps the "value" a ViewComponent's JSON encoding
+// carries in its config, e.g. {"metadata":{...},"config":{"value":"foo"}}.
+type cellValueEnvelope struct {
+	Config struct {
+		Value string `json:"value"`
+	} `json:"config"`
+}
+
+// cellString extracts a cell's display value for sorting/filtering,
+// without needing to know its concrete ViewComponent type.
+func cellString(cell ViewComponent) string {
+	if cell == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(cell)
+	if err != nil {
+		return ""
+	}
+
+	var env cellValueEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return ""
+	}
+
+	return env.Config.Value
+}
+
+// compareByType reports whether a < b, interpreting both according to
+// colType. Values that fail to parse as colType fall back to a plain
+// string comparison.
+func compareByType(a, b string, colType ColType) bool {
+	switch colType {
+	case ColTypeInt:
+		af, aerr := strconv.ParseFloat(a, 64)
+		bf, berr := strconv.ParseFloat(b, 64)
+		if aerr == nil && berr == nil {
+			return af < bf
+		}
+	case ColTypeQuantity:
+		aq, aerr := resource.ParseQuantity(a)
+		bq, berr := resource.ParseQuantity(b)
+		if aerr == nil && berr == nil {
+			return aq.Cmp(bq) < 0
+		}
+	case ColTypeTimestamp:
+		at, aerr := time.Parse(time.RFC3339, a)
+		bt, berr := time.Parse(time.RFC3339, b)
+		if aerr == nil && berr == nil {
+			return at.Before(bt)
+		}
+	case ColTypeDuration:
+		ad, aerr := parseK8sDuration(a)
+		bd, berr := parseK8sDuration(b)
+		if aerr == nil && berr == nil {
+			return ad < bd
+		}
+	}
+
+	return a < b
+}
+
+// parseK8sDuration parses a duration string that may carry a "d" (day)
+// unit ahead of anything time.ParseDuration already accepts, e.g. "5d" or
+// "2d3h", matching the age format kubectl-style columns use.
+func parseK8sDuration(s string) (time.Duration, error) {
+	idx := strings.IndexByte(s, 'd')
+	if idx == -1 {
+		return time.ParseDuration(s)
+	}
+
+	days, err := strconv.ParseFloat(s[:idx], 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parse day component of duration %q", s)
+	}
+
+	total := time.Duration(days * float64(24*time.Hour))
+
+	if rest := s[idx+1:]; rest != "" {
+		remainder, err := time.ParseDuration(rest)
+		if err != nil {
+			return 0, errors.Wrapf(err, "parse remainder of duration %q", s)
+		}
+		total += remainder
+	}
+
+	return total, nil
+}
+
+// rowMatchesFilter reports whether row satisfies filter, comparing its
+// cell's value according to colType.
+func rowMatchesFilter(row TableRow, filter TableFilter, colType ColType) bool {
+	cell, ok := row[filter.Column]
+	if !ok {
+		return false
+	}
+
+	value := cellString(cell)
+
+	switch filter.Op {
+	case FilterOpContains:
+		return strings.Contains(value, filter.Value)
+	case FilterOpGreaterThan:
+		return compareByType(filter.Value, value, colType)
+	case FilterOpLessThan:
+		return compareByType(value, filter.Value, colType)
+	case FilterOpEquals:
+		fallthrough
+	default:
+		return value == filter.Value
+	}
+}
+
+// TableRequest describes a client's requested sort, filters, and page for
+// a table, typically parsed from a `?sort=&filter=&page=` query string.
+type TableRequest struct {
+	Sort     *TableSort
+	Filters  []TableFilter
+	Page     int
+	PageSize int
+}
+
+// ParseTableRequest parses a `?sort=<column>|<column>:desc`,
+// `?filter=<column>:<op>:<value>` (repeatable), `?page=` and
+// `?pageSize=` query string into a TableRequest.
+func ParseTableRequest(query url.Values) (TableRequest, error) {
+	var req TableRequest
+
+	if sortParam := query.Get("sort"); sortParam != "" {
+		column := sortParam
+		descending := false
+		if strings.HasSuffix(sortParam, ":desc") {
+			column = strings.TrimSuffix(sortParam, ":desc")
+			descending = true
+		}
+		req.Sort = &TableSort{Column: column, Descending: descending}
+	}
+
+	for _, filterParam := range query["filter"] {
+		parts := strings.SplitN(filterParam, ":", 3)
+		if len(parts) != 3 {
+			return req, errors.Errorf("invalid filter %q, want column:op:value", filterParam)
+		}
+		req.Filters = append(req.Filters, TableFilter{
+			Column: parts[0],
+			Op:     FilterOp(parts[1]),
+			Value:  parts[2],
+		})
+	}
+
+	if pageParam := query.Get("page"); pageParam != "" {
+		page, err := strconv.Atoi(pageParam)
+		if err != nil {
+			return req, errors.Wrap(err, "parse page")
+		}
+		if page < 0 {
+			return req, errors.Errorf("page must be >= 0, got %d", page)
+		}
+		req.Page = page
+	}
+
+	if pageSizeParam := query.Get("pageSize"); pageSizeParam != "" {
+		pageSize, err := strconv.Atoi(pageSizeParam)
+		if err != nil {
+			return req, errors.Wrap(err, "parse pageSize")
+		}
+		if pageSize < 0 {
+			return req, errors.Errorf("pageSize must be >= 0, got %d", pageSize)
+		}
+		req.PageSize = pageSize
+	}
+
+	return req, nil
+}
+
+// ApplyTableQuery parses query and returns a new Table holding t's rows
+// with the query's filters, sort, and pagination applied, leaving t
+// untouched.
+func ApplyTableQuery(t *Table, query url.Values) (*Table, error) {
+	req, err := ParseTableRequest(query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]TableRow, len(t.Config.Rows))
+	copy(rows, t.Config.Rows)
+
+	result := &Table{
+		base: t.base,
+		Config: TableConfig{
+			Columns:      t.Config.Columns,
+			Rows:         rows,
+			EmptyContent: t.Config.EmptyContent,
+		},
+	}
+
+	for _, filter := range req.Filters {
+		result.AddFilter(filter)
+	}
+
+	if req.Sort != nil {
+		result.SetSort(req.Sort.Column, req.Sort.Descending)
+	}
+
+	if req.PageSize > 0 {
+		result.Paginate(req.Page, req.PageSize)
+	}
+
+	return result, nil
+}
+
 type tableMarshal Table
 
 // MarshalJSON implements json.Marshaler